@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/ZoraizSibtain/Data-Visualization-Agentic-Application/src/go/depotdb"
+	"github.com/ZoraizSibtain/Data-Visualization-Agentic-Application/src/go/migrations"
+)
+
+// depot doctor: pings the depot database, prints the server version, lists
+// the tables currently in robot_vacuum_depot, and checks the schema is at
+// the current migration head.
+func main() {
+	pgFlags := depotdb.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctx := context.Background()
+	cfg := pgFlags.Resolve()
+
+	db, err := depotdb.Open(ctx, cfg)
+	if err != nil {
+		log.Fatalf("X doctor: %v", err)
+	}
+	defer db.Close()
+	log.Printf("✔ Connected to %s:%d/%s", cfg.Host, cfg.Port, cfg.DBName)
+
+	var version string
+	if err := db.QueryRowContext(ctx, "SHOW server_version;").Scan(&version); err != nil {
+		log.Fatalf("X read server_version: %v", err)
+	}
+	log.Printf("✔ Server version: %s", version)
+
+	rows, err := db.QueryContext(ctx, `
+        SELECT table_name FROM information_schema.tables
+        WHERE table_schema = 'robot_vacuum_depot'
+        ORDER BY table_name;`)
+	if err != nil {
+		log.Fatalf("X list tables: %v", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			log.Fatalf("X scan table name: %v", err)
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Fatalf("X list tables: %v", err)
+	}
+	log.Printf("✔ %d table(s) in robot_vacuum_depot:", len(tables))
+	for _, t := range tables {
+		log.Printf("  ▸ %s", t)
+	}
+
+	pending, err := migrations.Plan(db)
+	if err != nil {
+		log.Fatalf("X check migration head: %v", err)
+	}
+	if len(pending) == 0 {
+		log.Println("✔ Schema is at the current migration head.")
+		return
+	}
+	log.Printf("▲ Schema is %d migration(s) behind head:", len(pending))
+	for _, mig := range pending {
+		log.Printf("  ▸ %04d_%s", mig.Version, mig.Name)
+	}
+}
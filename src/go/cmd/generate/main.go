@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/ZoraizSibtain/Data-Visualization-Agentic-Application/src/go/depotdb"
+)
+
+// Base cardinalities at scale=1, modeled loosely on Materialize's
+// load-generator sources (auction/TPCH/marketing style): a handful of
+// tunable knobs rather than a hand-curated CSV.
+const (
+	baseCustomers     = 1000
+	baseManufacturers = 50
+	baseProducts      = 500
+	baseWarehouses    = 20
+	baseDCs           = 5
+	baseOrders        = 10000
+	baseReviewRate    = 0.30
+)
+
+// generationAnchor is the fixed "today" orders/reviews are dated relative
+// to. Anchoring to wall-clock time would make the same seed+scale produce
+// different OrderDates on every run; a fixed anchor keeps output fully
+// reproducible.
+var generationAnchor = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func main() {
+	seed := flag.Int64("seed", 1, "seed for the deterministic pseudorandom generator")
+	scale := flag.Float64("scale", 1.0, "scale factor applied to every base cardinality")
+	tick := flag.Duration("tick", 0, "if > 0, stream new orders/reviews continuously at this interval instead of a one-shot batch")
+	output := flag.String("output", "csv", "where to write rows: csv (to stdout) or postgres (COPY)")
+	pgFlags := depotdb.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	rng := rand.New(rand.NewSource(*seed))
+
+	n := cardinalities(*scale)
+	log.Printf("Generating seed=%d scale=%.2f: %d customers, %d manufacturers, %d products, %d warehouses, %d DCs, %d orders",
+		*seed, *scale, n.customers, n.manufacturers, n.products, n.warehouses, n.dcs, n.orders)
+
+	gen := newGenerator(rng, n)
+
+	var sink rowSink
+	switch *output {
+	case "csv":
+		sink = newCSVSink(os.Stdout)
+	case "postgres":
+		db := depotdb.MustOpen(context.Background(), pgFlags.Resolve())
+		defer db.Close()
+		sink = newPostgresSink(db)
+	default:
+		log.Fatalf("X unknown --output %q (want csv or postgres)", *output)
+	}
+	defer sink.Close()
+
+	gen.writeMasterData(sink)
+
+	if *tick <= 0 {
+		gen.writeOrdersAndReviews(sink, n.orders)
+		log.Println("✔ Generation complete.")
+		return
+	}
+
+	log.Printf("Streaming new orders/reviews every %s (ctrl-c to stop)...", *tick)
+	for range time.Tick(*tick) {
+		gen.writeOrdersAndReviews(sink, 1)
+	}
+}
+
+type cardinality struct {
+	customers, manufacturers, products, warehouses, dcs, orders int
+}
+
+func cardinalities(scale float64) cardinality {
+	scaled := func(base int) int {
+		n := int(float64(base) * scale)
+		if n < 1 {
+			n = 1
+		}
+		return n
+	}
+	return cardinality{
+		customers:     scaled(baseCustomers),
+		manufacturers: scaled(baseManufacturers),
+		products:      scaled(baseProducts),
+		warehouses:    scaled(baseWarehouses),
+		dcs:           scaled(baseDCs),
+		orders:        scaled(baseOrders),
+	}
+}
+
+// rowSink is the output side of the generator: either a CSV writer to
+// stdout or a COPY stream straight into Postgres.
+type rowSink interface {
+	WriteRow(table string, columns []string, values []interface{})
+	Close()
+}
+
+type csvSink struct {
+	w *csv.Writer
+}
+
+func newCSVSink(f *os.File) *csvSink {
+	return &csvSink{w: csv.NewWriter(f)}
+}
+
+func (s *csvSink) WriteRow(table string, columns []string, values []interface{}) {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = fmt.Sprintf("%v", v)
+	}
+	s.w.Write(append([]string{table}, record...))
+	// Flush per row rather than relying on Close(): --tick streaming mode
+	// runs forever and only stops via an external signal, which skips
+	// the deferred Close() in main, so an unflushed row could otherwise
+	// sit in the bufio buffer indefinitely.
+	s.w.Flush()
+}
+
+func (s *csvSink) Close() { s.w.Flush() }
+
+type postgresSink struct {
+	db      *sql.DB
+	streams map[string]*copyStream
+}
+
+type copyStream struct {
+	tx   *sql.Tx
+	stmt *sql.Stmt
+}
+
+func newPostgresSink(db *sql.DB) *postgresSink {
+	return &postgresSink{db: db, streams: make(map[string]*copyStream)}
+}
+
+func (s *postgresSink) WriteRow(table string, columns []string, values []interface{}) {
+	stream, ok := s.streams[table]
+	if !ok {
+		tx, err := s.db.Begin()
+		if err != nil {
+			log.Fatalf("X begin COPY for %s: %v", table, err)
+		}
+		stmt, err := tx.Prepare(pq.CopyInSchema("robot_vacuum_depot", table, columns...))
+		if err != nil {
+			log.Fatalf("X prepare COPY for %s: %v", table, err)
+		}
+		stream = &copyStream{tx: tx, stmt: stmt}
+		s.streams[table] = stream
+	}
+	if _, err := stream.stmt.Exec(values...); err != nil {
+		log.Fatalf("X copy row into %s: %v", table, err)
+	}
+}
+
+func (s *postgresSink) Close() {
+	for table, stream := range s.streams {
+		if _, err := stream.stmt.Exec(); err != nil {
+			log.Printf("X finalize COPY for %s: %v", table, err)
+		}
+		stream.stmt.Close()
+		if err := stream.tx.Commit(); err != nil {
+			log.Printf("X commit COPY for %s: %v", table, err)
+		}
+	}
+}
+
+// generator holds the deterministic PRNG plus the ID pools later stages
+// (orders, reviews) need to reference.
+type generator struct {
+	rng  *rand.Rand
+	n    cardinality
+	zipf *rand.Zipf
+
+	manufacturerLeadTime []float64 // per-manufacturer LeadTimeDays skew
+}
+
+func newGenerator(rng *rand.Rand, n cardinality) *generator {
+	g := &generator{rng: rng, n: n}
+	// Zipf over ProductID: a handful of SKUs dominate order volume.
+	g.zipf = rand.NewZipf(rng, 1.5, 1.0, uint64(n.products-1))
+
+	g.manufacturerLeadTime = make([]float64, n.manufacturers)
+	for i := range g.manufacturerLeadTime {
+		g.manufacturerLeadTime[i] = 2 + rng.Float64()*12 // 2-14 day baseline lead time per manufacturer
+	}
+	return g
+}
+
+func (g *generator) writeMasterData(sink rowSink) {
+	for i := 0; i < g.n.customers; i++ {
+		sink.WriteRow("Customer", []string{"CustomerID", "CustomerName", "CustomerEmail", "CustomerStreetAddress", "CustomerZipCode", "BillingZipCode", "Segment"},
+			[]interface{}{customerID(i), fmt.Sprintf("Customer %d", i), fmt.Sprintf("customer%d@example.com", i),
+				fmt.Sprintf("%d Main St", 100+i), fmt.Sprintf("%05d", 10000+i%90000), fmt.Sprintf("%05d", 10000+i%90000), segment(g.rng)})
+	}
+	for i := 0; i < g.n.manufacturers; i++ {
+		sink.WriteRow("Manufacturer", []string{"ManufacturerID", "ManufacturerName", "Country", "LeadTimeDays", "ReliabilityScore"},
+			[]interface{}{manufacturerID(i), fmt.Sprintf("Manufacturer %d", i), country(g.rng), g.manufacturerLeadTime[i], 0.80 + g.rng.Float64()*0.20})
+	}
+	for i := 0; i < g.n.products; i++ {
+		mID := i % g.n.manufacturers
+		sink.WriteRow("Product", []string{"ProductID", "ProductName", "ModelNumber", "ManufacturerID", "UnitPrice", "ProductDescription"},
+			[]interface{}{productID(i), fmt.Sprintf("RoboVac %d", i), fmt.Sprintf("RV-%04d", i), manufacturerID(mID),
+				99 + g.rng.Float64()*400, "Synthetic product generated for benchmarking."})
+	}
+	for i := 0; i < g.n.warehouses; i++ {
+		sink.WriteRow("Warehouse", []string{"WarehouseID", "WarehouseStreetAddress", "WarehouseZipCode", "WarehouseCapacity"},
+			[]interface{}{warehouseID(i), fmt.Sprintf("%d Depot Rd", 200+i), fmt.Sprintf("%05d", 20000+i), 5000 + g.rng.Intn(20000)})
+	}
+	for i := 0; i < g.n.dcs; i++ {
+		sink.WriteRow("DistributionCenter", []string{"DistributionCenterID", "Region", "DistributionCenterStreetAddress", "DistributionCenterZipCode", "FleetSize"},
+			[]interface{}{dcID(i), region(g.rng), fmt.Sprintf("%d Logistics Way", 300+i), fmt.Sprintf("%05d", 30000+i), 10 + g.rng.Intn(90)})
+		sink.WriteRow("WarehouseDistributionCenter", []string{"WarehouseID", "DistributionCenterID"},
+			[]interface{}{warehouseID(i % g.n.warehouses), dcID(i)})
+	}
+}
+
+// writeOrdersAndReviews emits `count` new orders (plus a review roughly
+// baseReviewRate of the time) with OrderDate -> ExpectedDeliveryDate ->
+// ActualDeliveryDate spacing driven by the owning manufacturer's lead time.
+func (g *generator) writeOrdersAndReviews(sink rowSink, count int) {
+	for i := 0; i < count; i++ {
+		custIdx := g.rng.Intn(g.n.customers)
+		prodIdx := int(g.zipf.Uint64())
+		if prodIdx >= g.n.products {
+			prodIdx = g.n.products - 1
+		}
+		whIdx := g.rng.Intn(g.n.warehouses)
+		dcIdx := g.rng.Intn(g.n.dcs)
+		mfrIdx := prodIdx % g.n.manufacturers
+		leadTime := g.manufacturerLeadTime[mfrIdx]
+
+		orderDate := randomRecentDate(g.rng)
+		expected := orderDate.Add(time.Duration(leadTime) * 24 * time.Hour)
+		actual := expected.Add(time.Duration(g.rng.Intn(5)-2) * 24 * time.Hour) // +/- a couple days of jitter
+
+		quantity := 1 + g.rng.Intn(3)
+		unitPrice := 99 + g.rng.Float64()*400
+		total := float64(quantity) * unitPrice
+
+		orderID := fmt.Sprintf("ORD-%d-%d", g.rng.Int63(), i)
+		sink.WriteRow("Order", []string{"OrderID", "CustomerID", "ProductID", "WarehouseID", "DistributionCenterID",
+			"Quantity", "UnitPrice", "DiscountAmount", "PromoCode", "TaxAmount", "ShippingCost",
+			"CostOfGoods", "TotalAmount", "OrderDate", "ExpectedDeliveryDate", "ActualDeliveryDate",
+			"DeliveryStatus", "PaymentMethod", "CardNumber", "CardBrand", "BillingZipCode",
+			"DeliveryStreetAddress", "DeliveryZipCode", "ShippingCarrier"},
+			[]interface{}{orderID, customerID(custIdx), productID(prodIdx), warehouseID(whIdx), dcID(dcIdx),
+				quantity, unitPrice, 0.0, "", total * 0.07, 9.99,
+				unitPrice * 0.6, total, orderDate, expected, actual,
+				"Delivered", "Card", "", "Visa", fmt.Sprintf("%05d", 10000+custIdx%90000),
+				fmt.Sprintf("%d Main St", 100+custIdx), fmt.Sprintf("%05d", 10000+custIdx%90000), "Synthetic Freight"})
+
+		if g.rng.Float64() < baseReviewRate {
+			reviewDate := actual.Add(time.Duration(1+g.rng.Intn(10)) * 24 * time.Hour)
+			sink.WriteRow("Review", []string{"ReviewID", "OrderID", "CustomerID", "ProductID", "ProductRating", "ReviewText", "ReviewDate", "ReviewSentiment"},
+				[]interface{}{fmt.Sprintf("REV-%s", orderID), orderID, customerID(custIdx), productID(prodIdx),
+					1 + g.rng.Intn(5), "Synthetic review generated for benchmarking.", reviewDate, sentiment(g.rng)})
+		}
+	}
+}
+
+func customerID(i int) string     { return fmt.Sprintf("CUST-%06d", i) }
+func manufacturerID(i int) string { return fmt.Sprintf("MFR-%04d", i) }
+func productID(i int) string      { return fmt.Sprintf("PROD-%06d", i) }
+func warehouseID(i int) string    { return fmt.Sprintf("WH-%04d", i) }
+func dcID(i int) string           { return fmt.Sprintf("DC-%04d", i) }
+
+func randomRecentDate(rng *rand.Rand) time.Time {
+	daysAgo := rng.Intn(730) // within the last 2 years of generationAnchor
+	return generationAnchor.AddDate(0, 0, -daysAgo)
+}
+
+func segment(rng *rand.Rand) string {
+	segments := []string{"Consumer", "Small Business", "Enterprise"}
+	return segments[rng.Intn(len(segments))]
+}
+
+func country(rng *rand.Rand) string {
+	countries := []string{"USA", "China", "Germany", "South Korea", "Japan"}
+	return countries[rng.Intn(len(countries))]
+}
+
+func region(rng *rand.Rand) string {
+	regions := []string{"Northeast", "Southeast", "Midwest", "West", "Southwest"}
+	return regions[rng.Intn(len(regions))]
+}
+
+func sentiment(rng *rand.Rand) string {
+	sentiments := []string{"Positive", "Neutral", "Negative"}
+	return sentiments[rng.Intn(len(sentiments))]
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestCardinalitiesScaling(t *testing.T) {
+	n := cardinalities(2.0)
+	want := cardinality{
+		customers:     2 * baseCustomers,
+		manufacturers: 2 * baseManufacturers,
+		products:      2 * baseProducts,
+		warehouses:    2 * baseWarehouses,
+		dcs:           2 * baseDCs,
+		orders:        2 * baseOrders,
+	}
+	if n != want {
+		t.Fatalf("cardinalities(2.0) = %+v, want %+v", n, want)
+	}
+}
+
+func TestCardinalitiesFloorsAtOne(t *testing.T) {
+	n := cardinalities(0.0001)
+	if n.customers != 1 || n.manufacturers != 1 || n.products != 1 ||
+		n.warehouses != 1 || n.dcs != 1 || n.orders != 1 {
+		t.Fatalf("cardinalities(0.0001) = %+v, want every field floored to 1", n)
+	}
+}
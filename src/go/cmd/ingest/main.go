@@ -0,0 +1,568 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/ZoraizSibtain/Data-Visualization-Agentic-Application/src/go/depotdb"
+	"github.com/ZoraizSibtain/Data-Visualization-Agentic-Application/src/go/partition"
+)
+
+const (
+	csvFile = "RobotVacuumDepot_MasterData.csv"
+
+	defaultBatchSize = 5000
+)
+
+// tableLoadOrder is the FK-safe order in which staging tables are flushed
+// into their real counterparts.
+var tableLoadOrder = []string{
+	"Customer",
+	"Manufacturer",
+	"Product",
+	"Warehouse",
+	"DistributionCenter",
+	"WarehouseDistributionCenter",
+	"WarehouseProductStock",
+	"Order",
+	"Review",
+}
+
+// tableColumns lists, in COPY order, the columns each table stages.
+var tableColumns = map[string][]string{
+	"Customer":                    {"CustomerID", "CustomerName", "CustomerEmail", "CustomerStreetAddress", "CustomerZipCode", "BillingZipCode", "Segment"},
+	"Manufacturer":                {"ManufacturerID", "ManufacturerName", "Country", "LeadTimeDays", "ReliabilityScore"},
+	"Product":                     {"ProductID", "ProductName", "ModelNumber", "ManufacturerID", "UnitPrice", "ProductDescription"},
+	"Warehouse":                   {"WarehouseID", "WarehouseStreetAddress", "WarehouseZipCode", "WarehouseCapacity"},
+	"DistributionCenter":          {"DistributionCenterID", "Region", "DistributionCenterStreetAddress", "DistributionCenterZipCode", "FleetSize"},
+	"WarehouseDistributionCenter": {"WarehouseID", "DistributionCenterID"},
+	"WarehouseProductStock":       {"WarehouseID", "ProductID", "StockLevel", "RestockThreshold", "LastRestockDate", "LastUpdateDate"},
+	"Order": {"OrderID", "CustomerID", "ProductID", "WarehouseID", "DistributionCenterID",
+		"Quantity", "UnitPrice", "DiscountAmount", "PromoCode", "TaxAmount", "ShippingCost",
+		"CostOfGoods", "TotalAmount", "OrderDate", "ExpectedDeliveryDate", "ActualDeliveryDate",
+		"DeliveryStatus", "PaymentMethod", "CardNumber", "CardBrand", "BillingZipCode",
+		"DeliveryStreetAddress", "DeliveryZipCode", "ShippingCarrier"},
+	"Review": {"ReviewID", "OrderID", "CustomerID", "ProductID", "ProductRating", "ReviewText", "ReviewDate", "ReviewSentiment"},
+}
+
+// tableBatch accumulates deduplicated rows for one table until it is
+// flushed to Postgres via COPY.
+type tableBatch struct {
+	table string
+	seen  map[string]bool // dedup on natural/primary key within the batch
+	rows  [][]interface{}
+}
+
+func newTableBatch(table string) *tableBatch {
+	return &tableBatch{table: table, seen: make(map[string]bool)}
+}
+
+// add appends a row if its key hasn't already been staged in this batch.
+// An empty key means the row has no usable natural key and is skipped.
+func (b *tableBatch) add(key string, row []interface{}) {
+	if key == "" || b.seen[key] {
+		return
+	}
+	b.seen[key] = true
+	b.rows = append(b.rows, row)
+}
+
+func (b *tableBatch) reset() {
+	b.seen = make(map[string]bool)
+	b.rows = b.rows[:0]
+}
+
+func main() {
+	start := time.Now()
+
+	batchSize := flag.Int("batch-size", defaultBatchSize, "rows buffered per table before a COPY flush")
+	strict := flag.Bool("strict", false, "abort on the first coercion/truncation/orphan-reference problem")
+	rejectFilePath := flag.String("reject-file", "", "if set, malformed rows are also written here for re-processing")
+	lookaheadMonths := flag.Int("partition-lookahead-months", partition.DefaultLookaheadMonths, "months of Order/Review partitions to pre-create past the newest one found")
+	retention := flag.Duration("partition-retention", 0, "if set, detach monthly Order/Review partitions entirely older than this (e.g. 8760h); 0 keeps all partitions attached")
+	pgFlags := depotdb.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	if *batchSize <= 0 {
+		log.Fatalf("X --batch-size must be > 0, got %d", *batchSize)
+	}
+
+	db := depotdb.MustOpen(context.Background(), pgFlags.Resolve())
+	defer db.Close()
+	log.Println("✔ Connected to PostgreSQL.")
+
+	if err := partition.ManageOrderAndReviewPartitions(db, *lookaheadMonths, *retention); err != nil {
+		log.Fatalf("X manage partitions: %v", err)
+	}
+	log.Println("✔ Order/Review partitions up to date.")
+
+	if err := createStagingTables(db); err != nil {
+		log.Fatalf("X create staging tables: %v", err)
+	}
+	log.Println("✔ Staging tables ready.")
+
+	file, err := os.Open(csvFile)
+	if err != nil {
+		log.Fatalf("X open CSV: %v", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	headers, err := reader.Read()
+	if err != nil {
+		log.Fatalf("X read header: %v", err)
+	}
+
+	headerMap := make(map[string]int)
+	for i, h := range headers {
+		headerMap[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	log.Printf("✔ Loaded %d CSV columns (header-based mapping enabled).", len(headerMap))
+
+	batches := make(map[string]*tableBatch, len(tableLoadOrder))
+	for _, t := range tableLoadOrder {
+		batches[t] = newTableBatch(t)
+	}
+
+	counts := make(map[string]int, len(tableLoadOrder))
+	elapsedByTable := make(map[string]time.Duration, len(tableLoadOrder))
+
+	flush := func() error {
+		for _, t := range tableLoadOrder {
+			b := batches[t]
+			if len(b.rows) == 0 {
+				continue
+			}
+			if err := ensurePartitionsForBatch(db, b); err != nil {
+				return fmt.Errorf("ensure partitions for %s: %w", t, err)
+			}
+			n, d, err := copyBatch(db, b)
+			if err != nil {
+				return fmt.Errorf("flush %s: %w", t, err)
+			}
+			counts[t] += n
+			elapsedByTable[t] += d
+			b.reset()
+		}
+		return nil
+	}
+
+	qr := newQualityReport(*strict)
+	var rejectWriter *csv.Writer
+	if *rejectFilePath != "" {
+		rejectFile, err := os.Create(*rejectFilePath)
+		if err != nil {
+			log.Fatalf("X create reject file: %v", err)
+		}
+		defer rejectFile.Close()
+		rejectWriter = csv.NewWriter(rejectFile)
+		rejectWriter.Write(append([]string{"reject_reason"}, headers...))
+	}
+
+	rowCount := 0
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("X read record: %v", err)
+		}
+		rowCount++
+		issuesBefore := len(qr.issues)
+
+		if err := stageRowByHeader(batches, rec, headerMap, qr, rowCount); err != nil {
+			log.Fatalf("X %v", err)
+		}
+
+		if len(qr.issues) > issuesBefore {
+			qr.rejectedRows++
+			if rejectWriter != nil {
+				rejectWriter.Write(append([]string{qr.issues[len(qr.issues)-1].Kind}, rec...))
+			}
+		} else {
+			qr.acceptedRows++
+		}
+
+		if rowCount%*batchSize == 0 {
+			if err := flush(); err != nil {
+				log.Fatalf("X %v", err)
+			}
+			log.Printf("Processed %d rows...", rowCount)
+		}
+	}
+	if err := flush(); err != nil {
+		log.Fatalf("X %v", err)
+	}
+	if rejectWriter != nil {
+		rejectWriter.Flush()
+	}
+
+	if err := loadFromStaging(db, counts); err != nil {
+		log.Fatalf("X load from staging: %v", err)
+	}
+
+	if err := qr.WriteReport("ingest_report.json", 10); err != nil {
+		log.Fatalf("X write quality report: %v", err)
+	}
+
+	log.Println("---")
+	log.Println("Per-table COPY summary:")
+	for _, t := range tableLoadOrder {
+		log.Printf("☑ %-28s staged=%-8d copy_time=%s", t, counts[t], elapsedByTable[t])
+	}
+	log.Printf("✓ Ingestion complete: %d source rows in %s.", rowCount, time.Since(start))
+}
+
+// createStagingTables creates one UNLOGGED staging table per real table,
+// shaped identically but without keys, foreign keys or indexes, so COPY
+// doesn't check them. LIKE still copies NOT NULL constraints.
+func createStagingTables(db *sql.DB) error {
+	for _, t := range tableLoadOrder {
+		ddl := fmt.Sprintf(
+			`CREATE UNLOGGED TABLE IF NOT EXISTS robot_vacuum_depot.%q (LIKE robot_vacuum_depot.%q INCLUDING DEFAULTS);`,
+			t+"_stage", t)
+		if _, err := db.Exec(ddl); err != nil {
+			return fmt.Errorf("staging table %s: %w", t, err)
+		}
+		if _, err := db.Exec(fmt.Sprintf(`TRUNCATE robot_vacuum_depot.%q;`, t+"_stage")); err != nil {
+			return fmt.Errorf("truncate staging table %s: %w", t, err)
+		}
+	}
+	return nil
+}
+
+// ensurePartitionsForBatch pre-creates whatever monthly Order/Review
+// partitions this batch's rows need before COPY runs, so late-arriving
+// dates always have somewhere to land.
+func ensurePartitionsForBatch(db *sql.DB, b *tableBatch) error {
+	dateCol := -1
+	var ensure func(*sql.DB, time.Time) error
+	switch b.table {
+	case "Order":
+		dateCol, ensure = 13, partition.EnsureOrderPartition // "OrderDate"
+	case "Review":
+		dateCol, ensure = 6, partition.EnsureReviewPartition // "ReviewDate"
+	default:
+		return nil
+	}
+
+	seenMonths := make(map[string]bool)
+	for _, row := range b.rows {
+		t, ok := row[dateCol].(time.Time)
+		if !ok || t.Equal(missingPartitionDate) {
+			continue // unparseable/blank date; deliberately left for the DEFAULT partition to catch
+		}
+		key := t.Format("2006-01")
+		if seenMonths[key] {
+			continue
+		}
+		seenMonths[key] = true
+		if err := ensure(db, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyBatch streams a batch's buffered rows into its staging table via
+// pq.CopyIn and reports how many rows were sent and how long it took.
+func copyBatch(db *sql.DB, b *tableBatch) (int, time.Duration, error) {
+	if len(b.rows) == 0 {
+		return 0, 0, nil
+	}
+	start := time.Now()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(pq.CopyInSchema("robot_vacuum_depot", b.table+"_stage", tableColumns[b.table]...))
+	if err != nil {
+		return 0, 0, fmt.Errorf("prepare COPY: %w", err)
+	}
+
+	for _, row := range b.rows {
+		if _, err := stmt.Exec(row...); err != nil {
+			stmt.Close()
+			return 0, 0, fmt.Errorf("copy row: %w", err)
+		}
+	}
+	if _, err := stmt.Exec(); err != nil {
+		stmt.Close()
+		return 0, 0, fmt.Errorf("finalize COPY: %w", err)
+	}
+	if err := stmt.Close(); err != nil {
+		return 0, 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+
+	return len(b.rows), time.Since(start), nil
+}
+
+// loadFromStaging moves staged rows into the real tables in FK-safe order,
+// skipping rows that already exist.
+func loadFromStaging(db *sql.DB, counts map[string]int) error {
+	for _, t := range tableLoadOrder {
+		if counts[t] == 0 {
+			continue
+		}
+		start := time.Now()
+		cols := tableColumns[t]
+		quoted := make([]string, len(cols))
+		for i, c := range cols {
+			quoted[i] = fmt.Sprintf("%q", c)
+		}
+		colList := strings.Join(quoted, ", ")
+		conflictCol := primaryKeyColumns(t)
+
+		insertSQL := fmt.Sprintf(
+			`INSERT INTO robot_vacuum_depot.%q (%s) SELECT %s FROM robot_vacuum_depot.%q ON CONFLICT (%s) DO NOTHING;`,
+			t, colList, colList, t+"_stage", conflictCol)
+
+		res, err := db.Exec(insertSQL)
+		if err != nil {
+			return fmt.Errorf("load %s from staging: %w", t, err)
+		}
+		n, _ := res.RowsAffected()
+		log.Printf("☑ %-28s loaded=%-8d elapsed=%s", t, n, time.Since(start))
+	}
+	return nil
+}
+
+// primaryKeyColumns returns the quoted ON CONFLICT target for a table.
+func primaryKeyColumns(table string) string {
+	switch table {
+	case "WarehouseDistributionCenter":
+		return `"WarehouseID", "DistributionCenterID"`
+	case "WarehouseProductStock":
+		return `"WarehouseID", "ProductID"`
+	case "Customer":
+		return `"CustomerID"`
+	case "Manufacturer":
+		return `"ManufacturerID"`
+	case "Product":
+		return `"ProductID"`
+	case "Warehouse":
+		return `"WarehouseID"`
+	case "DistributionCenter":
+		return `"DistributionCenterID"`
+	case "Order":
+		// Partitioned by OrderDate (migration 0002): the partition key must
+		// be part of any unique constraint, so the primary key is composite.
+		return `"OrderID", "OrderDate"`
+	case "Review":
+		return `"ReviewID", "ReviewDate"`
+	}
+	return ""
+}
+
+// stageRowByHeader maps one CSV record into its per-table rows and stages
+// each into the matching batch, deduplicating on natural key. Every
+// coercion, truncation, and orphan reference is recorded in qr rather than
+// silently coerced; in --strict mode the first one aborts the row with an
+// error.
+func stageRowByHeader(batches map[string]*tableBatch, rec []string, hdr map[string]int, qr *qualityReport, row int) error {
+	var issueErr error
+	note := func(table, field, kind, raw, detail string) {
+		if issueErr != nil {
+			return // already aborting this row in strict mode
+		}
+		issueErr = qr.record(row, table, field, kind, raw, detail)
+	}
+
+	get := func(name string) string {
+		idx, ok := hdr[strings.ToLower(name)]
+		if !ok || idx >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[idx])
+	}
+
+	intOrWarn := func(table, field, v string) int {
+		if v == "" {
+			return 0
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			note(table, field, "bad_int", v, err.Error())
+			return 0
+		}
+		return n
+	}
+
+	floatOrWarn := func(table, field, v string) float64 {
+		if v == "" {
+			return 0
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			note(table, field, "bad_float", v, err.Error())
+			return 0
+		}
+		return f
+	}
+
+	parseDate := func(table, field, v string) time.Time {
+		if v == "" {
+			return time.Time{}
+		}
+		v = strings.TrimSpace(v)
+		layouts := []string{
+			"2006-01-02 15:04:05",
+			"2006-01-02 15:04",
+			"01/02/2006 15:04:05",
+			"01/02/2006 15:04",
+			"01/02/2006 3:04 PM",
+			"1/2/2006 3:04 PM",
+			"1/2/2006 15:04:05",
+			"1/2/2006 15:04",
+			"1/2/2006 7:04",
+			"1/2/2006 07:04",
+			"01/02/2006",
+			"1/2/2006",
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t
+			}
+		}
+		if strings.Count(v, ":") == 1 && !strings.Contains(v, "M") {
+			if t, err := time.Parse("1/2/2006 3:04 PM", v+" PM"); err == nil {
+				return t
+			}
+		}
+		note(table, field, "bad_date", v, "no matching layout")
+		return time.Time{}
+	}
+
+	truncate := func(table, field, s string, max int) string {
+		if len(s) > max {
+			note(table, field, "truncated", s, fmt.Sprintf("%d -> %d chars", len(s), max))
+			return s[:max]
+		}
+		return s
+	}
+
+	orphan := func(table, field, ownerID, refID string) {
+		if ownerID != "" && refID == "" {
+			note(table, field, "orphan_ref", refID, fmt.Sprintf("%s present but %s missing", table, field))
+		}
+	}
+
+	customerID := get("customerid")
+	manufacturerID := get("manufacturerid")
+	productID := get("productid")
+	warehouseID := get("warehouseid")
+	dcID := get("distributioncenterid")
+	orderID := get("orderid")
+	reviewID := get("reviewid")
+
+	batches["Customer"].add(customerID, []interface{}{
+		customerID, truncate("Customer", "CustomerName", get("customername"), 120), truncate("Customer", "CustomerEmail", get("customeremail"), 120),
+		truncate("Customer", "CustomerStreetAddress", get("customeraddress"), 200), truncate("Customer", "CustomerZipCode", get("customerzipcode"), 20),
+		truncate("Customer", "BillingZipCode", get("billingzipcode"), 20), truncate("Customer", "Segment", get("segment"), 60),
+	})
+
+	batches["Manufacturer"].add(manufacturerID, []interface{}{
+		manufacturerID, truncate("Manufacturer", "ManufacturerName", get("manufacturername"), 120), truncate("Manufacturer", "Country", get("country"), 60),
+		floatOrWarn("Manufacturer", "LeadTimeDays", get("leadtimedays")), floatOrWarn("Manufacturer", "ReliabilityScore", get("reliabilityscore")),
+	})
+
+	orphan("Product", "ManufacturerID", productID, manufacturerID)
+	batches["Product"].add(productID, []interface{}{
+		productID, truncate("Product", "ProductName", get("productname"), 160), truncate("Product", "ModelNumber", get("modelnumber"), 80),
+		manufacturerID, floatOrWarn("Product", "UnitPrice", get("productprice")), get("productdescription"),
+	})
+
+	batches["Warehouse"].add(warehouseID, []interface{}{
+		warehouseID, truncate("Warehouse", "WarehouseStreetAddress", get("warehousestreetaddress"), 200), truncate("Warehouse", "WarehouseZipCode", get("warehousezipcode"), 20),
+		intOrWarn("Warehouse", "WarehouseCapacity", get("warehousecapacity")),
+	})
+
+	batches["DistributionCenter"].add(dcID, []interface{}{
+		dcID, truncate("DistributionCenter", "Region", get("region"), 60), truncate("DistributionCenter", "DistributionCenterStreetAddress", get("distributioncenterstreetaddress"), 200),
+		truncate("DistributionCenter", "DistributionCenterZipCode", get("distributioncenterzipcode"), 20), intOrWarn("DistributionCenter", "FleetSize", get("fleetsize")),
+	})
+
+	if warehouseID != "" && dcID != "" {
+		batches["WarehouseDistributionCenter"].add(warehouseID+"|"+dcID, []interface{}{warehouseID, dcID})
+	}
+
+	if warehouseID != "" && productID != "" {
+		lastRestock := parseDate("WarehouseProductStock", "LastRestockDate", get("lastrestockdate"))
+		lastUpdate := parseDate("WarehouseProductStock", "LastUpdateDate", get("lastupdated"))
+		batches["WarehouseProductStock"].add(warehouseID+"|"+productID, []interface{}{
+			warehouseID, productID, intOrWarn("WarehouseProductStock", "StockLevel", get("stocklevel")), intOrWarn("WarehouseProductStock", "RestockThreshold", get("restockthreshold")),
+			nullableTime(lastRestock), nullableTime(lastUpdate),
+		})
+	}
+
+	orphan("Order", "CustomerID", orderID, customerID)
+	orphan("Order", "ProductID", orderID, productID)
+	orphan("Order", "WarehouseID", orderID, warehouseID)
+	orphan("Order", "DistributionCenterID", orderID, dcID)
+	batches["Order"].add(orderID, []interface{}{
+		orderID, customerID, productID, warehouseID, dcID,
+		intOrWarn("Order", "Quantity", get("quantity")), floatOrWarn("Order", "UnitPrice", get("unitprice")), floatOrWarn("Order", "DiscountAmount", get("discountamount")),
+		truncate("Order", "PromoCode", get("promocode"), 80), floatOrWarn("Order", "TaxAmount", get("taxamount")), floatOrWarn("Order", "ShippingCost", get("shippingcost")),
+		floatOrWarn("Order", "CostOfGoods", get("costofgoods")), floatOrWarn("Order", "TotalAmount", get("totalamount")),
+		partitionKeyTime(parseDate("Order", "OrderDate", get("orderdate"))), nullableTime(parseDate("Order", "ExpectedDeliveryDate", get("expecteddeliverydate"))),
+		nullableTime(parseDate("Order", "ActualDeliveryDate", get("actualdeliverydate"))), truncate("Order", "DeliveryStatus", get("deliverystatus"), 40),
+		truncate("Order", "PaymentMethod", get("paymentmethod"), 20), truncate("Order", "CardNumber", get("cardnumber"), 30), truncate("Order", "CardBrand", get("cardbrand"), 40),
+		truncate("Order", "BillingZipCode", get("billingzipcode"), 20), truncate("Order", "DeliveryStreetAddress", get("deliveryaddress"), 200),
+		truncate("Order", "DeliveryZipCode", get("deliveryzipcode"), 20), truncate("Order", "ShippingCarrier", get("shippingcarrier"), 80),
+	})
+
+	if reviewID != "" {
+		orphan("Review", "OrderID", reviewID, orderID)
+		batches["Review"].add(reviewID, []interface{}{
+			reviewID, orderID, customerID, productID, intOrWarn("Review", "ProductRating", get("reviewrating")),
+			get("reviewtext"), partitionKeyTime(parseDate("Review", "ReviewDate", get("reviewdate"))), truncate("Review", "ReviewSentiment", get("reviewsentiment"), 20),
+		})
+	}
+
+	return issueErr
+}
+
+// nullableTime turns a zero time.Time (our "unparseable" sentinel) into a
+// nil so COPY stores a real SQL NULL instead of the Postgres epoch.
+func nullableTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// missingPartitionDate is what an unparseable/blank OrderDate or
+// ReviewDate becomes. Both columns are part of their table's primary key
+// (a requirement of range partitioning, see migration 0002), so unlike
+// the other date columns they can never hold a real SQL NULL -- COPY
+// would fail the whole batch at finalize. ensurePartitionsForBatch never
+// creates a dedicated monthly partition for this sentinel, so every row
+// that carries it falls through to the catch-all DEFAULT partition
+// instead.
+var missingPartitionDate = time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// partitionKeyTime is nullableTime's counterpart for OrderDate/ReviewDate.
+func partitionKeyTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return missingPartitionDate
+	}
+	return t
+}
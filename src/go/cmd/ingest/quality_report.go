@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+)
+
+// qualityIssue is one coercion, truncation, or orphan-reference problem
+// found while staging a single CSV row.
+type qualityIssue struct {
+	Row    int    `json:"row"`
+	Table  string `json:"table"`
+	Field  string `json:"field"`
+	Kind   string `json:"kind"` // bad_int, bad_float, bad_date, truncated, orphan_ref
+	Raw    string `json:"raw"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// qualityReport collects every coercion problem the ingester hits so they
+// can be reported instead of silently coerced to zero values, as the old
+// commented-out log.Printf calls used to do.
+type qualityReport struct {
+	strict       bool
+	issues       []qualityIssue
+	acceptedRows int
+	rejectedRows int
+}
+
+func newQualityReport(strict bool) *qualityReport {
+	return &qualityReport{strict: strict}
+}
+
+// record notes an issue and, in --strict mode, turns it into an error the
+// caller can use to abort the run on the first bad value.
+func (qr *qualityReport) record(row int, table, field, kind, raw, detail string) error {
+	qr.issues = append(qr.issues, qualityIssue{Row: row, Table: table, Field: field, Kind: kind, Raw: raw, Detail: detail})
+	if qr.strict {
+		return fmt.Errorf("strict mode: row %d %s.%s %s %q: %s", row, table, field, kind, raw, detail)
+	}
+	return nil
+}
+
+type fieldOffense struct {
+	Field string `json:"field"`
+	Count int    `json:"count"`
+}
+
+// topOffendingFields returns the n columns with the most recorded issues,
+// worst first.
+func (qr *qualityReport) topOffendingFields(n int) []fieldOffense {
+	byField := make(map[string]int)
+	for _, iss := range qr.issues {
+		byField[iss.Table+"."+iss.Field]++
+	}
+	offenders := make([]fieldOffense, 0, len(byField))
+	for field, count := range byField {
+		offenders = append(offenders, fieldOffense{Field: field, Count: count})
+	}
+	sort.Slice(offenders, func(i, j int) bool { return offenders[i].Count > offenders[j].Count })
+	if len(offenders) > n {
+		offenders = offenders[:n]
+	}
+	return offenders
+}
+
+// worstRows returns up to n issues, ranked by how many issues their row
+// accumulated (most first, ties broken by row number), so "worst" means
+// what it says rather than just insertion order.
+func (qr *qualityReport) worstRows(n int) []qualityIssue {
+	issuesPerRow := make(map[int]int)
+	for _, iss := range qr.issues {
+		issuesPerRow[iss.Row]++
+	}
+
+	sample := make([]qualityIssue, len(qr.issues))
+	copy(sample, qr.issues)
+	sort.SliceStable(sample, func(i, j int) bool {
+		ci, cj := issuesPerRow[sample[i].Row], issuesPerRow[sample[j].Row]
+		if ci != cj {
+			return ci > cj
+		}
+		return sample[i].Row < sample[j].Row
+	})
+	if len(sample) > n {
+		sample = sample[:n]
+	}
+	return sample
+}
+
+// WriteReport writes the machine-readable report to path and logs a human
+// summary: acceptance/rejection counts, the top-N offending columns, and a
+// sample of the worst rows.
+func (qr *qualityReport) WriteReport(path string, topN int) error {
+	sample := qr.worstRows(topN)
+
+	report := struct {
+		AcceptedRows    int            `json:"accepted_rows"`
+		RejectedRows    int            `json:"rejected_rows"`
+		TotalIssues     int            `json:"total_issues"`
+		TopOffenders    []fieldOffense `json:"top_offending_columns"`
+		SampleWorstRows []qualityIssue `json:"sample_worst_rows"`
+	}{
+		AcceptedRows:    qr.acceptedRows,
+		RejectedRows:    qr.rejectedRows,
+		TotalIssues:     len(qr.issues),
+		TopOffenders:    qr.topOffendingFields(topN),
+		SampleWorstRows: sample,
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+
+	log.Println("---")
+	log.Printf("Data quality summary: %d accepted rows, %d rejected rows, %d total issue(s).",
+		qr.acceptedRows, qr.rejectedRows, len(qr.issues))
+	for _, o := range report.TopOffenders {
+		log.Printf("  ▲ %-40s %d issue(s)", o.Field, o.Count)
+	}
+	for _, iss := range sample {
+		log.Printf("  ▸ row %-8d %s.%s %s %q", iss.Row, iss.Table, iss.Field, iss.Kind, iss.Raw)
+	}
+	log.Printf("✔ Full quality report written to %s", path)
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import "testing"
+
+func TestTopOffendingFields(t *testing.T) {
+	qr := newQualityReport(false)
+	qr.issues = []qualityIssue{
+		{Row: 1, Table: "Order", Field: "Quantity", Kind: "bad_int"},
+		{Row: 2, Table: "Order", Field: "Quantity", Kind: "bad_int"},
+		{Row: 3, Table: "Order", Field: "Quantity", Kind: "bad_int"},
+		{Row: 1, Table: "Review", Field: "ProductRating", Kind: "bad_int"},
+		{Row: 2, Table: "Review", Field: "ProductRating", Kind: "bad_int"},
+		{Row: 1, Table: "Order", Field: "OrderDate", Kind: "bad_date"},
+	}
+
+	got := qr.topOffendingFields(2)
+	want := []fieldOffense{
+		{Field: "Order.Quantity", Count: 3},
+		{Field: "Review.ProductRating", Count: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("topOffendingFields(2) = %+v, want %+v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("offender %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWorstRowsRanksByIssueCount(t *testing.T) {
+	qr := newQualityReport(false)
+	// Row 5 has one issue, row 2 has three, row 9 has two: insertion order
+	// deliberately doesn't match the expected rank order.
+	qr.issues = []qualityIssue{
+		{Row: 5, Field: "A", Kind: "bad_int"},
+		{Row: 2, Field: "B", Kind: "bad_int"},
+		{Row: 9, Field: "C", Kind: "bad_int"},
+		{Row: 2, Field: "D", Kind: "bad_int"},
+		{Row: 9, Field: "E", Kind: "bad_int"},
+		{Row: 2, Field: "F", Kind: "bad_int"},
+	}
+
+	sample := qr.worstRows(10)
+	if len(sample) != len(qr.issues) {
+		t.Fatalf("worstRows(10) returned %d issue(s), want %d", len(sample), len(qr.issues))
+	}
+
+	// Row 2 (3 issues) must come before row 9 (2 issues), which must come
+	// before row 5 (1 issue); issues of the same row stay contiguous.
+	order := make([]int, len(sample))
+	for i, iss := range sample {
+		order[i] = iss.Row
+	}
+	wantOrder := []int{2, 2, 2, 9, 9, 5}
+	for i := range wantOrder {
+		if order[i] != wantOrder[i] {
+			t.Fatalf("worstRows(10) row order = %v, want %v", order, wantOrder)
+		}
+	}
+}
+
+func TestWorstRowsRespectsLimit(t *testing.T) {
+	qr := newQualityReport(false)
+	for i := 0; i < 5; i++ {
+		qr.issues = append(qr.issues, qualityIssue{Row: i, Field: "A", Kind: "bad_int"})
+	}
+	if got := qr.worstRows(2); len(got) != 2 {
+		t.Fatalf("worstRows(2) returned %d issue(s), want 2", len(got))
+	}
+}
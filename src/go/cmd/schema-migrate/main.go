@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/ZoraizSibtain/Data-Visualization-Agentic-Application/src/go/depotdb"
+	"github.com/ZoraizSibtain/Data-Visualization-Agentic-Application/src/go/migrations"
+)
+
+func main() {
+	start := time.Now()
+
+	dryRun := flag.Bool("dry-run", false, "print the pending migration plan without applying it")
+	downSteps := flag.Int("down", 0, "roll back this many applied migrations instead of applying pending ones")
+	pgFlags := depotdb.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	cfg := pgFlags.Resolve()
+
+	log.Printf("Connecting to PostgreSQL at %s:%d ...", cfg.Host, cfg.Port)
+	db := depotdb.MustOpen(context.Background(), cfg)
+	defer db.Close()
+	log.Println("✔ Connected to PostgreSQL successfully.")
+
+	if *downSteps > 0 {
+		if err := migrations.Down(db, *downSteps); err != nil {
+			log.Fatalf("X ERROR rolling back migrations: %v", err)
+		}
+		log.Printf("Rollback complete in %s", time.Since(start))
+		return
+	}
+
+	if err := migrations.Run(db, *dryRun); err != nil {
+		log.Fatalf("X ERROR applying migrations: %v", err)
+	}
+
+	elapsed := time.Since(start)
+	log.Printf("Schema migration complete in %s", elapsed)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/ZoraizSibtain/Data-Visualization-Agentic-Application/src/go/depotdb"
+)
+
+func main() {
+	// Connection parameters are resolved by depotdb: DEPOT_PG_* env vars,
+	// then depot.yaml, then these flag defaults.
+	pgFlags := depotdb.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
+	db := depotdb.MustOpen(context.Background(), pgFlags.Resolve())
+	defer db.Close()
+
+	fmt.Println("Connected to PostgreSQL successfully!")
+
+	// Example: create schema to test
+	if _, err := db.Exec("CREATE SCHEMA IF NOT EXISTS robot_vacuum_depot;"); err != nil {
+		log.Fatalf("Error creating schema: %v", err)
+	}
+	fmt.Println("Schema created successfully.")
+}
@@ -0,0 +1,174 @@
+// Package depotdb centralizes how every tool in this repo connects to the
+// Robot Vacuum Depot database, so credentials never have to live in
+// source. Settings are resolved in this order for each field: the
+// DEPOT_PG_* environment variables take priority, then depot.yaml in the
+// working directory, and finally whatever was passed on the command line
+// (see RegisterFlags).
+package depotdb
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	_ "github.com/lib/pq"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds everything needed to open a connection pool to the depot
+// database.
+type Config struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	DBName   string
+	SSLMode  string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+func (c Config) dsn() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode)
+}
+
+// Flags are the CLI-flag-backed defaults a tool registers with
+// RegisterFlags; they're the lowest-priority source Resolve falls back to.
+type Flags struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	DBName   string
+	SSLMode  string
+}
+
+// RegisterFlags adds --pg-host/--pg-port/etc. to fs and returns the struct
+// they're bound to. Call Resolve() after fs.Parse() to get the final Config.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{}
+	fs.StringVar(&f.Host, "pg-host", "127.0.0.1", "Postgres host")
+	fs.IntVar(&f.Port, "pg-port", 5432, "Postgres port")
+	fs.StringVar(&f.User, "pg-user", "postgres", "Postgres user")
+	fs.StringVar(&f.Password, "pg-password", "root", "Postgres password")
+	fs.StringVar(&f.DBName, "pg-dbname", "robotvacuum", "Postgres database name")
+	fs.StringVar(&f.SSLMode, "pg-sslmode", "disable", "Postgres sslmode")
+	return f
+}
+
+// yamlConfig mirrors the subset of depot.yaml we understand.
+type yamlConfig struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	DBName   string `yaml:"dbname"`
+	SSLMode  string `yaml:"sslmode"`
+}
+
+// Resolve builds the final Config: start from the CLI flag values, overlay
+// depot.yaml if one is present, then overlay the DEPOT_PG_* environment
+// variables, since those should win in a deployed environment.
+func (f *Flags) Resolve() Config {
+	cfg := Config{
+		Host: f.Host, Port: f.Port, User: f.User, Password: f.Password,
+		DBName: f.DBName, SSLMode: f.SSLMode,
+		MaxOpenConns:    10,
+		MaxIdleConns:    5,
+		ConnMaxLifetime: 30 * time.Minute,
+	}
+
+	if y, ok := loadYAML("depot.yaml"); ok {
+		if y.Host != "" {
+			cfg.Host = y.Host
+		}
+		if y.Port != 0 {
+			cfg.Port = y.Port
+		}
+		if y.User != "" {
+			cfg.User = y.User
+		}
+		if y.Password != "" {
+			cfg.Password = y.Password
+		}
+		if y.DBName != "" {
+			cfg.DBName = y.DBName
+		}
+		if y.SSLMode != "" {
+			cfg.SSLMode = y.SSLMode
+		}
+	}
+
+	cfg.Host = envOr("DEPOT_PG_HOST", cfg.Host)
+	cfg.Port = envOrInt("DEPOT_PG_PORT", cfg.Port)
+	cfg.User = envOr("DEPOT_PG_USER", cfg.User)
+	cfg.Password = envOr("DEPOT_PG_PASSWORD", cfg.Password)
+	cfg.DBName = envOr("DEPOT_PG_DB", cfg.DBName)
+	cfg.SSLMode = envOr("DEPOT_PG_SSLMODE", cfg.SSLMode)
+
+	return cfg
+}
+
+func loadYAML(path string) (yamlConfig, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return yamlConfig{}, false
+	}
+	var y yamlConfig
+	if err := yaml.Unmarshal(data, &y); err != nil {
+		return yamlConfig{}, false
+	}
+	return y, true
+}
+
+func envOr(key, fallback string) string {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		return v
+	}
+	return fallback
+}
+
+func envOrInt(key string, fallback int) int {
+	if v, ok := os.LookupEnv(key); ok && v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+// Open connects to Postgres with cfg, configures the pool, and pings the
+// server, so an unreachable database or bad credentials fail here rather
+// than on the first query.
+func Open(ctx context.Context, cfg Config) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.dsn())
+	if err != nil {
+		return nil, fmt.Errorf("open depot database: %w", err)
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping depot database: %w", err)
+	}
+	return db, nil
+}
+
+// MustOpen is Open for one-shot scripts that would just log.Fatal anyway.
+func MustOpen(ctx context.Context, cfg Config) *sql.DB {
+	db, err := Open(ctx, cfg)
+	if err != nil {
+		log.Fatalf("X %v", err)
+	}
+	return db
+}
@@ -0,0 +1,94 @@
+package depotdb
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withWorkingDir chdirs into dir for the duration of the test and restores
+// the original working directory on cleanup.
+func withWorkingDir(t *testing.T, dir string) {
+	t.Helper()
+	orig, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("Chdir(%s): %v", dir, err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(orig); err != nil {
+			t.Fatalf("restore Chdir(%s): %v", orig, err)
+		}
+	})
+}
+
+func clearDepotEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"DEPOT_PG_HOST", "DEPOT_PG_PORT", "DEPOT_PG_USER", "DEPOT_PG_PASSWORD", "DEPOT_PG_DB", "DEPOT_PG_SSLMODE"} {
+		if v, ok := os.LookupEnv(key); ok {
+			os.Unsetenv(key)
+			t.Cleanup(func() { os.Setenv(key, v) })
+		}
+	}
+}
+
+func TestResolveFallsBackToFlagsWithNoYAMLOrEnv(t *testing.T) {
+	clearDepotEnv(t)
+	withWorkingDir(t, t.TempDir())
+
+	f := &Flags{Host: "flag-host", Port: 1111, User: "flag-user", Password: "flag-pass", DBName: "flag-db", SSLMode: "require"}
+	cfg := f.Resolve()
+
+	if cfg.Host != "flag-host" || cfg.Port != 1111 || cfg.User != "flag-user" ||
+		cfg.Password != "flag-pass" || cfg.DBName != "flag-db" || cfg.SSLMode != "require" {
+		t.Fatalf("Resolve() = %+v, want flag values unchanged", cfg)
+	}
+}
+
+func TestResolveYAMLOverridesFlags(t *testing.T) {
+	clearDepotEnv(t)
+	dir := t.TempDir()
+	withWorkingDir(t, dir)
+
+	yaml := "host: yaml-host\nport: 2222\nuser: yaml-user\n"
+	if err := os.WriteFile(filepath.Join(dir, "depot.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatalf("write depot.yaml: %v", err)
+	}
+
+	f := &Flags{Host: "flag-host", Port: 1111, User: "flag-user", Password: "flag-pass", DBName: "flag-db", SSLMode: "disable"}
+	cfg := f.Resolve()
+
+	if cfg.Host != "yaml-host" || cfg.Port != 2222 || cfg.User != "yaml-user" {
+		t.Errorf("Resolve() = %+v, want yaml.Host/Port/User to override flags", cfg)
+	}
+	// depot.yaml didn't set password/dbname/sslmode, so those fall back to
+	// the flag values unchanged.
+	if cfg.Password != "flag-pass" || cfg.DBName != "flag-db" || cfg.SSLMode != "disable" {
+		t.Errorf("Resolve() = %+v, want unset yaml fields to keep flag values", cfg)
+	}
+}
+
+func TestResolveEnvOverridesYAMLAndFlags(t *testing.T) {
+	clearDepotEnv(t)
+	dir := t.TempDir()
+	withWorkingDir(t, dir)
+
+	yaml := "host: yaml-host\nport: 2222\n"
+	if err := os.WriteFile(filepath.Join(dir, "depot.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatalf("write depot.yaml: %v", err)
+	}
+
+	os.Setenv("DEPOT_PG_HOST", "env-host")
+	t.Cleanup(func() { os.Unsetenv("DEPOT_PG_HOST") })
+	os.Setenv("DEPOT_PG_PORT", "3333")
+	t.Cleanup(func() { os.Unsetenv("DEPOT_PG_PORT") })
+
+	f := &Flags{Host: "flag-host", Port: 1111}
+	cfg := f.Resolve()
+
+	if cfg.Host != "env-host" || cfg.Port != 3333 {
+		t.Fatalf("Resolve() = %+v, want DEPOT_PG_* env vars to win over both yaml and flags", cfg)
+	}
+}
@@ -0,0 +1,360 @@
+// Package migrations applies numbered, forward-only SQL migrations to the
+// robot_vacuum_depot schema and records what has run in
+// robot_vacuum_depot.schema_migrations, so schema changes no longer require
+// a destructive DROP SCHEMA CASCADE.
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed sql/*.sql
+var sqlFS embed.FS
+
+// advisoryLockKey namespaces the advisory lock so unrelated tools sharing
+// the same database don't collide with migration runs.
+const advisoryLockKey = 0x726f626f74 // "robot" in hex, arbitrary but stable
+
+var fileNamePattern = regexp.MustCompile(`^(\d{4})_([a-zA-Z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is one numbered, reversible schema change.
+type Migration struct {
+	Version  int
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string // sha-256 of UpSQL, used to detect drift on already-applied migrations
+}
+
+// Load reads and pairs every *.up.sql/*.down.sql file under sql/, sorted
+// by version.
+func Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(sqlFS, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations dir: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		m := fileNamePattern.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		version, _ := strconv.Atoi(m[1])
+		name, direction := m[2], m[3]
+
+		contents, err := sqlFS.ReadFile("sql/" + e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", e.Name(), err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: name}
+			byVersion[version] = mig
+		}
+		switch direction {
+		case "up":
+			mig.UpSQL = string(contents)
+			sum := sha256.Sum256(contents)
+			mig.Checksum = hex.EncodeToString(sum[:])
+		case "down":
+			mig.DownSQL = string(contents)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+type appliedRecord struct {
+	checksum  string
+	appliedAt string
+}
+
+// ensureVersionTable creates the bookkeeping table if it doesn't exist yet.
+func ensureVersionTable(db *sql.DB) error {
+	_, err := db.Exec(`CREATE SCHEMA IF NOT EXISTS robot_vacuum_depot;`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`
+        CREATE TABLE IF NOT EXISTS robot_vacuum_depot.schema_migrations(
+            version     INT PRIMARY KEY,
+            name        TEXT NOT NULL,
+            checksum    TEXT NOT NULL,
+            applied_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+        );`)
+	return err
+}
+
+func appliedVersions(db *sql.DB) (map[int]appliedRecord, error) {
+	rows, err := db.Query(`SELECT version, checksum, applied_at FROM robot_vacuum_depot.schema_migrations;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]appliedRecord)
+	for rows.Next() {
+		var version int
+		var rec appliedRecord
+		if err := rows.Scan(&version, &rec.checksum, &rec.appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = rec
+	}
+	return applied, rows.Err()
+}
+
+// Plan returns the migrations that still need to run, after checking that
+// every already-applied migration's checksum still matches what's on disk.
+func Plan(db *sql.DB) ([]Migration, error) {
+	if err := ensureVersionTable(db); err != nil {
+		return nil, fmt.Errorf("ensure schema_migrations: %w", err)
+	}
+
+	all, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+
+	var pending []Migration
+	for _, mig := range all {
+		rec, ok := applied[mig.Version]
+		if !ok {
+			pending = append(pending, mig)
+			continue
+		}
+		if rec.checksum != mig.Checksum {
+			return nil, fmt.Errorf("drift detected: migration %04d_%s was applied with checksum %s but the file on disk now hashes to %s",
+				mig.Version, mig.Name, rec.checksum, mig.Checksum)
+		}
+	}
+	return pending, nil
+}
+
+// Run applies every pending migration inside its own transaction, holding
+// a session-level advisory lock for the duration so concurrent runs don't
+// race each other. The lock is acquired and released on a single dedicated
+// connection (pg_advisory_lock is session-scoped, so taking it through the
+// pool proper could hand the lock and unlock to different backends and
+// leak it until that connection's lifetime expired). If dryRun is true,
+// Run only prints the plan (Plan still creates the schema and the
+// schema_migrations table if they are missing).
+func Run(db *sql.DB, dryRun bool) error {
+	pending, err := Plan(db)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		log.Println("✔ No pending migrations; schema is up to date.")
+		return nil
+	}
+
+	if dryRun {
+		log.Printf("Dry run: %d pending migration(s):", len(pending))
+		for _, mig := range pending {
+			log.Printf("  ▸ %04d_%s.up.sql", mig.Version, mig.Name)
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire dedicated connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1);`, advisoryLockKey); err != nil {
+		return fmt.Errorf("acquire advisory lock: %w", err)
+	}
+	defer conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1);`, advisoryLockKey)
+
+	// Plan again under the lock: a concurrent run may have applied some of
+	// the migrations between the first Plan and acquiring the lock.
+	pending, err = Plan(db)
+	if err != nil {
+		return err
+	}
+
+	for _, mig := range pending {
+		if err := applyOne(ctx, conn, mig); err != nil {
+			return fmt.Errorf("apply %04d_%s: %w", mig.Version, mig.Name, err)
+		}
+		log.Printf("☑ Applied migration %04d_%s", mig.Version, mig.Name)
+	}
+	return nil
+}
+
+func applyOne(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range splitStatements(mig.UpSQL) {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("exec: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO robot_vacuum_depot.schema_migrations(version, name, checksum) VALUES ($1, $2, $3);`,
+		mig.Version, mig.Name, mig.Checksum)
+	if err != nil {
+		return fmt.Errorf("record version: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// Down rolls back the most recently applied `steps` migrations, in
+// descending version order, using their paired .down.sql files.
+//
+// Because steps can be greater than 1, each down.sql must leave the schema
+// exactly as the migration below it left it, not just reverse its own
+// up.sql in isolation -- the next file in the chain runs immediately
+// afterwards and will fail if it assumes something this one tore down.
+// (0004_partition_order_review_cutover.down.sql exists to restore
+// 0003's post-up state, not just collapse "Order"/"Review" on their own,
+// for exactly this reason.)
+func Down(db *sql.DB, steps int) error {
+	if err := ensureVersionTable(db); err != nil {
+		return err
+	}
+	all, err := Load()
+	if err != nil {
+		return err
+	}
+	byVersion := make(map[int]Migration, len(all))
+	for _, mig := range all {
+		byVersion[mig.Version] = mig
+	}
+
+	applied, err := appliedVersions(db)
+	if err != nil {
+		return err
+	}
+	versions := make([]int, 0, len(applied))
+	for v := range applied {
+		versions = append(versions, v)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	if steps > len(versions) {
+		steps = len(versions)
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire dedicated connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1);`, advisoryLockKey); err != nil {
+		return fmt.Errorf("acquire advisory lock: %w", err)
+	}
+	defer conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1);`, advisoryLockKey)
+
+	for i := 0; i < steps; i++ {
+		version := versions[i]
+		mig, ok := byVersion[version]
+		if !ok || mig.DownSQL == "" {
+			return fmt.Errorf("no down.sql available for applied version %04d", version)
+		}
+
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		for _, stmt := range splitStatements(mig.DownSQL) {
+			if _, err := tx.Exec(stmt); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("rollback %04d_%s: %w", mig.Version, mig.Name, err)
+			}
+		}
+		if _, err := tx.Exec(`DELETE FROM robot_vacuum_depot.schema_migrations WHERE version = $1;`, version); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		log.Printf("☑ Reverted migration %04d_%s", mig.Version, mig.Name)
+	}
+	return nil
+}
+
+// dollarQuotePattern matches a dollar-quote delimiter ("$$" or "$tag$") at
+// the start of the given string.
+var dollarQuotePattern = regexp.MustCompile(`^\$\w*\$`)
+
+// splitStatements breaks a migration file into individual statements on
+// top-level ";" boundaries, treating dollar-quoted bodies ($$...$$ or
+// $tag$...$tag$, as used by CREATE FUNCTION and DO blocks) as opaque --
+// semicolons inside them don't end a statement.
+func splitStatements(sqlText string) []string {
+	var stmts []string
+	var cur strings.Builder
+	tag := "" // the closing delimiter (e.g. "$$") while inside a dollar-quoted body, else ""
+
+	for i := 0; i < len(sqlText); {
+		switch {
+		case tag == "" && strings.HasPrefix(sqlText[i:], "--"):
+			if nl := strings.IndexByte(sqlText[i:], '\n'); nl >= 0 {
+				i += nl + 1
+			} else {
+				i = len(sqlText)
+			}
+		case tag == "" && sqlText[i] == '$':
+			if m := dollarQuotePattern.FindString(sqlText[i:]); m != "" {
+				tag = m
+				cur.WriteString(m)
+				i += len(m)
+			} else {
+				cur.WriteByte(sqlText[i])
+				i++
+			}
+		case tag != "" && strings.HasPrefix(sqlText[i:], tag):
+			cur.WriteString(tag)
+			i += len(tag)
+			tag = ""
+		case tag == "" && sqlText[i] == ';':
+			if s := strings.TrimSpace(cur.String()); s != "" {
+				stmts = append(stmts, s)
+			}
+			cur.Reset()
+			i++
+		default:
+			cur.WriteByte(sqlText[i])
+			i++
+		}
+	}
+	if s := strings.TrimSpace(cur.String()); s != "" {
+		stmts = append(stmts, s)
+	}
+	return stmts
+}
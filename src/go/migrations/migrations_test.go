@@ -0,0 +1,102 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want []string
+	}{
+		{
+			name: "plain statements with a comment",
+			sql: `-- a comment
+CREATE TABLE foo(id INT);
+CREATE TABLE bar(id INT);`,
+			want: []string{
+				"CREATE TABLE foo(id INT)",
+				"CREATE TABLE bar(id INT)",
+			},
+		},
+		{
+			name: "dollar-quoted function body keeps its internal semicolons",
+			sql: `CREATE FUNCTION f() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM t WHERE id = 1;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER t_trg AFTER INSERT ON t FOR EACH ROW EXECUTE FUNCTION f();`,
+			want: []string{
+				"CREATE FUNCTION f() RETURNS trigger AS $$\nBEGIN\n    DELETE FROM t WHERE id = 1;\n    RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql",
+				"CREATE TRIGGER t_trg AFTER INSERT ON t FOR EACH ROW EXECUTE FUNCTION f()",
+			},
+		},
+		{
+			name: "tagged dollar-quote ($tag$) and a DO block with internal semicolons",
+			sql: `DO $$
+DECLARE
+    batch_size CONSTANT INT := 5000;
+BEGIN
+    LOOP
+        EXIT WHEN batch_size = 0;
+    END LOOP;
+END $$;
+
+CREATE FUNCTION g() RETURNS int AS $tag$ SELECT 1; $tag$ LANGUAGE sql;`,
+			want: []string{
+				"DO $$\nDECLARE\n    batch_size CONSTANT INT := 5000;\nBEGIN\n    LOOP\n        EXIT WHEN batch_size = 0;\n    END LOOP;\nEND $$",
+				"CREATE FUNCTION g() RETURNS int AS $tag$ SELECT 1; $tag$ LANGUAGE sql",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitStatements(tt.sql)
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitStatements returned %d statement(s), want %d:\ngot:  %q\nwant: %q", len(got), len(tt.want), got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("statement %d:\ngot:  %q\nwant: %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestSplitStatementsOnRealMigrations guards against the actual bug this
+// test was added for: feeding 0002/0003's embedded SQL (which contain
+// CREATE FUNCTION ... $$...$$ bodies and a DO $$...$$ backfill loop) through
+// splitStatements must not shred them into syntactically invalid fragments
+// like "RETURN NEW" or "END IF" as separate statements.
+func TestSplitStatementsOnRealMigrations(t *testing.T) {
+	all, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	for _, mig := range all {
+		for _, sql := range []string{mig.UpSQL, mig.DownSQL} {
+			if sql == "" {
+				continue
+			}
+			for _, stmt := range splitStatements(sql) {
+				if strings.Contains(stmt, "$$") || strings.Contains(stmt, "DO $") {
+					openers := strings.Count(stmt, "$$")
+					if openers%2 != 0 && !strings.Contains(stmt, "LANGUAGE") {
+						t.Errorf("migration %04d_%s: unbalanced dollar-quote in statement %q", mig.Version, mig.Name, stmt)
+					}
+				}
+				if stmt == "RETURN NEW" || stmt == "RETURN OLD" || stmt == "END IF" || strings.TrimSpace(stmt) == "END $$" {
+					t.Errorf("migration %04d_%s: dollar-quoted body was shredded into fragment %q", mig.Version, mig.Name, stmt)
+				}
+			}
+		}
+	}
+}
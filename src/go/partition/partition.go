@@ -0,0 +1,165 @@
+// Package partition manages the monthly RANGE partitions on
+// robot_vacuum_depot."Order" and "Review" (see migration 0002), inspired by
+// TiDB's interval partitioning: pre-create partitions ahead of the data
+// and retire ones past their retention window.
+package partition
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// DefaultLookaheadMonths is how many months of partitions
+// ManageOrderAndReviewPartitions pre-creates beyond the newest one found.
+const DefaultLookaheadMonths = 3
+
+// EnsureOrderPartition makes sure the monthly partition covering t exists
+// on "Order", creating it if necessary. The ingester calls this before
+// COPYing a batch so late-arriving rows always have a partition to land in.
+func EnsureOrderPartition(db *sql.DB, t time.Time) error {
+	return ensureMonthlyPartition(db, "Order", t)
+}
+
+// EnsureReviewPartition is the "Review" analogue of EnsureOrderPartition.
+func EnsureReviewPartition(db *sql.DB, t time.Time) error {
+	return ensureMonthlyPartition(db, "Review", t)
+}
+
+func ensureMonthlyPartition(db *sql.DB, table string, t time.Time) error {
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	partName := partitionName(table, start)
+
+	ddl := fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS robot_vacuum_depot.%q PARTITION OF robot_vacuum_depot.%q FOR VALUES FROM (%s) TO (%s);`,
+		partName, table, quoteDate(start), quoteDate(end))
+	_, err := db.Exec(ddl)
+	return err
+}
+
+func partitionName(table string, month time.Time) string {
+	return fmt.Sprintf("%s_y%04dm%02d", table, month.Year(), month.Month())
+}
+
+func quoteDate(t time.Time) string {
+	return "'" + t.Format("2006-01-02") + "'"
+}
+
+var partitionNamePattern = regexp.MustCompile(`^(.+)_y(\d{4})m(\d{2})$`)
+
+// ManageOrderAndReviewPartitions is meant to run once per connection open:
+// for both "Order" and "Review" it pre-creates the next aheadMonths of
+// monthly partitions past whatever is newest on disk (or the current month
+// if none exist yet), and, when retention > 0, detaches partitions whose
+// entire date range has aged out of the retention window.
+func ManageOrderAndReviewPartitions(db *sql.DB, aheadMonths int, retention time.Duration) error {
+	if aheadMonths <= 0 {
+		aheadMonths = DefaultLookaheadMonths
+	}
+	for _, table := range []string{"Order", "Review"} {
+		anchor, err := latestPartitionMonth(db, table)
+		if err != nil {
+			return fmt.Errorf("inspect partitions for %s: %w", table, err)
+		}
+		for i := 0; i <= aheadMonths; i++ {
+			if err := ensureMonthlyPartition(db, table, anchor.AddDate(0, i, 0)); err != nil {
+				return fmt.Errorf("pre-create partition for %s: %w", table, err)
+			}
+		}
+		if retention > 0 {
+			if err := detachOldPartitions(db, table, retention); err != nil {
+				return fmt.Errorf("retire old partitions for %s: %w", table, err)
+			}
+		}
+	}
+	return nil
+}
+
+// childPartitions returns the names of every partition currently attached
+// to the given parent table.
+func childPartitions(db *sql.DB, table string) ([]string, error) {
+	rows, err := db.Query(`
+        SELECT c.relname
+        FROM pg_inherits i
+        JOIN pg_class c ON c.oid = i.inhrelid
+        JOIN pg_class p ON p.oid = i.inhparent
+        WHERE p.relname = $1;`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// latestPartitionMonth returns the first day of the newest month that
+// already has a dated partition, or the current month if none do yet.
+func latestPartitionMonth(db *sql.DB, table string) (time.Time, error) {
+	names, err := childPartitions(db, table)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var latest time.Time
+	for _, name := range names {
+		month, ok := parsePartitionMonth(name)
+		if !ok {
+			continue // e.g. the DEFAULT partition
+		}
+		if month.After(latest) {
+			latest = month
+		}
+	}
+	if latest.IsZero() {
+		now := time.Now().UTC()
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), nil
+	}
+	return latest, nil
+}
+
+func parsePartitionMonth(name string) (time.Time, bool) {
+	m := partitionNamePattern.FindStringSubmatch(name)
+	if m == nil {
+		return time.Time{}, false
+	}
+	year, _ := strconv.Atoi(m[2])
+	month, _ := strconv.Atoi(m[3])
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), true
+}
+
+// detachOldPartitions detaches (rather than drops, so the data stays
+// recoverable) any monthly partition whose upper bound falls before the
+// retention cutoff.
+func detachOldPartitions(db *sql.DB, table string, retention time.Duration) error {
+	cutoff := time.Now().UTC().Add(-retention)
+
+	names, err := childPartitions(db, table)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		month, ok := parsePartitionMonth(name)
+		if !ok {
+			continue
+		}
+		if month.AddDate(0, 1, 0).After(cutoff) {
+			continue
+		}
+		ddl := fmt.Sprintf(`ALTER TABLE robot_vacuum_depot.%q DETACH PARTITION robot_vacuum_depot.%q;`, table, name)
+		if _, err := db.Exec(ddl); err != nil {
+			return fmt.Errorf("detach %s: %w", name, err)
+		}
+	}
+	return nil
+}
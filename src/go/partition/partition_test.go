@@ -0,0 +1,46 @@
+package partition
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPartitionName(t *testing.T) {
+	tests := []struct {
+		table string
+		month time.Time
+		want  string
+	}{
+		{"Order", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), "Order_y2024m01"},
+		{"Review", time.Date(2024, time.December, 15, 0, 0, 0, 0, time.UTC), "Review_y2024m12"},
+	}
+	for _, tt := range tests {
+		if got := partitionName(tt.table, tt.month); got != tt.want {
+			t.Errorf("partitionName(%q, %v) = %q, want %q", tt.table, tt.month, got, tt.want)
+		}
+	}
+}
+
+func TestParsePartitionMonth(t *testing.T) {
+	tests := []struct {
+		name      string
+		want      time.Time
+		wantFound bool
+	}{
+		{"Order_y2024m01", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), true},
+		{"Review_y2026m07", time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC), true},
+		{"Order_partitioned_default", time.Time{}, false},
+		{"not a partition name", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parsePartitionMonth(tt.name)
+			if ok != tt.wantFound {
+				t.Fatalf("parsePartitionMonth(%q) ok = %v, want %v", tt.name, ok, tt.wantFound)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("parsePartitionMonth(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}